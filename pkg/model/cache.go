@@ -0,0 +1,30 @@
+package model
+
+import "fmt"
+
+// RemoteCacheRef builds a registry ref for a pre-built build-cache image
+// keyed by CUDA, cuDNN and Python version, e.g.
+// "registry/python-cache:cog-v1-cuda-11.8.0-cudnn-8-py3.10".
+func RemoteCacheRef(registry string, cuda string, cuDNN string, python string) string {
+	return fmt.Sprintf("%s:cog-v1-cuda-%s-cudnn-%s-py%s", registry, cuda, cuDNN, python)
+}
+
+// PublishBaseImages returns the cache tags an operator needs to pre-warm a
+// registry with, one per distinct CUDA/cuDNN combo and requested Python
+// version. CUDA base images repeat the same CUDA/cuDNN combo across their
+// devel/runtime tags, so combos are deduped before building refs.
+func (r *Registry) PublishBaseImages(registry string, pythons []string) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	for _, image := range r.CUDA {
+		key := image.CUDA + "/" + image.CuDNN
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		for _, python := range pythons {
+			refs = append(refs, RemoteCacheRef(registry, image.CUDA, image.CuDNN, python))
+		}
+	}
+	return refs
+}
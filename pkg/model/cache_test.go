@@ -0,0 +1,56 @@
+package model
+
+import "testing"
+
+func TestRemoteCacheRef(t *testing.T) {
+	got := RemoteCacheRef("registry", "11.8.0", "8", "3.10")
+	want := "registry:cog-v1-cuda-11.8.0-cudnn-8-py3.10"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestPublishBaseImages(t *testing.T) {
+	r := &Registry{
+		CUDA: []CUDABaseImage{
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8.0", CuDNN: "8"},
+			{Tag: "12.1.0-cudnn8-devel-ubuntu22.04", CUDA: "12.1.0", CuDNN: "8"},
+		},
+	}
+
+	refs := r.PublishBaseImages("registry", []string{"3.10", "3.11"})
+
+	want := []string{
+		"registry:cog-v1-cuda-11.8.0-cudnn-8-py3.10",
+		"registry:cog-v1-cuda-11.8.0-cudnn-8-py3.11",
+		"registry:cog-v1-cuda-12.1.0-cudnn-8-py3.10",
+		"registry:cog-v1-cuda-12.1.0-cudnn-8-py3.11",
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("expected %d refs, got %d: %v", len(want), len(refs), refs)
+	}
+	for i, ref := range refs {
+		if ref != want[i] {
+			t.Errorf("ref[%d] = %s, want %s", i, ref, want[i])
+		}
+	}
+}
+
+func TestPublishBaseImagesDedupesDevelRuntimePairs(t *testing.T) {
+	r := &Registry{
+		CUDA: []CUDABaseImage{
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8.0", CuDNN: "8", IsDevel: true},
+			{Tag: "11.8.0-cudnn8-runtime-ubuntu22.04", CUDA: "11.8.0", CuDNN: "8", IsDevel: false},
+		},
+	}
+
+	refs := r.PublishBaseImages("registry", []string{"3.10"})
+
+	want := []string{"registry:cog-v1-cuda-11.8.0-cudnn-8-py3.10"}
+	if len(refs) != len(want) {
+		t.Fatalf("expected %d refs, got %d: %v", len(want), len(refs), refs)
+	}
+	if refs[0] != want[0] {
+		t.Errorf("expected %s, got %s", want[0], refs[0])
+	}
+}
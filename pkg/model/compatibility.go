@@ -1,14 +1,11 @@
 package model
 
 import (
-	_ "embed"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
-
 	"github.com/replicate/modelserver/pkg/version"
 )
 
@@ -66,6 +63,11 @@ type CUDABaseImage struct {
 	CuDNN   string
 	IsDevel bool
 	Ubuntu  string
+	// Capabilities is the set of CUDA compute capabilities (e.g. "sm_80")
+	// this image's CUDA/cuDNN combo was built to support. It is populated
+	// separately from cuda_capabilities_matrix.json, since it isn't part
+	// of the image tag itself.
+	Capabilities []string
 }
 
 func (i *CUDABaseImage) UnmarshalJSON(data []byte) error {
@@ -91,33 +93,9 @@ func (i *CUDABaseImage) ImageTag() string {
 
 //go:generate go run ../../cmd/generate_compatibility_matrices/main.go -tf-output tf_compatability_matrix.json -torch-output torch_compatability_matrix.json -cuda-images-output cuda_base_image_tags.json
 
-//go:embed tf_compatability_matrix.json
-var tfCompatibilityMatrixData []byte
-var TFCompatibilityMatrix []TFCompatibility
-
-//go:embed torch_compatability_matrix.json
-var torchCompatibilityMatrixData []byte
-var TorchCompatibilityMatrix []TorchCompatibility
-
-//go:embed cuda_base_image_tags.json
-var cudaBaseImageTagsData []byte
-var CUDABaseImages []CUDABaseImage
-
-func init() {
-	if err := json.Unmarshal(tfCompatibilityMatrixData, &TFCompatibilityMatrix); err != nil {
-		log.Fatalf("Failed to load embedded Tensorflow compatibility matrix: %s", err)
-	}
-	if err := json.Unmarshal(torchCompatibilityMatrixData, &TorchCompatibilityMatrix); err != nil {
-		log.Fatalf("Failed to load embedded PyTorch compatibility matrix: %s", err)
-	}
-	if err := json.Unmarshal(cudaBaseImageTagsData, &CUDABaseImages); err != nil {
-		log.Fatalf("Failed to load embedded CUDA base images: %s", err)
-	}
-}
-
-func cudasFromTorch(ver string) ([]string, error) {
+func (r *Registry) cudasFromTorch(ver string) ([]string, error) {
 	cudas := []string{}
-	for _, compat := range TorchCompatibilityMatrix {
+	for _, compat := range r.Torch {
 		if ver == compat.TorchVersion() && compat.CUDA != nil {
 			cudas = append(cudas, *compat.CUDA)
 		}
@@ -128,8 +106,8 @@ func cudasFromTorch(ver string) ([]string, error) {
 	return cudas, nil
 }
 
-func cudaFromTF(ver string) (cuda string, cuDNN string, err error) {
-	for _, compat := range TFCompatibilityMatrix {
+func (r *Registry) cudaFromTF(ver string) (cuda string, cuDNN string, err error) {
+	for _, compat := range r.TF {
 		if ver == compat.TF {
 			return compat.CUDA, compat.CuDNN, nil
 		}
@@ -137,9 +115,9 @@ func cudaFromTF(ver string) (cuda string, cuDNN string, err error) {
 	return "", "", fmt.Errorf("tensorflow==%s doesn't have any compatible CUDA versions", ver)
 }
 
-func compatibleCuDNNsForCUDA(cuda string) []string {
+func (r *Registry) compatibleCuDNNsForCUDA(cuda string) []string {
 	cuDNNs := []string{}
-	for _, image := range CUDABaseImages {
+	for _, image := range r.CUDA {
 		if image.CUDA == cuda {
 			cuDNNs = append(cuDNNs, image.CuDNN)
 		}
@@ -147,8 +125,8 @@ func compatibleCuDNNsForCUDA(cuda string) []string {
 	return cuDNNs
 }
 
-func defaultCUDA() string {
-	return latestTF().CUDA
+func (r *Registry) defaultCUDA() string {
+	return r.latestTF().CUDA
 }
 
 func latestCUDAFrom(cudas []string) string {
@@ -170,9 +148,9 @@ func latestCUDAFrom(cudas []string) string {
 	return latest
 }
 
-func latestCuDNNForCUDA(cuda string) string {
+func (r *Registry) latestCuDNNForCUDA(cuda string) string {
 	cuDNNs := []string{}
-	for _, image := range CUDABaseImages {
+	for _, image := range r.CUDA {
 		if image.CUDA == cuda {
 			cuDNNs = append(cuDNNs, image.CuDNN)
 		}
@@ -183,9 +161,9 @@ func latestCuDNNForCUDA(cuda string) string {
 	return cuDNNs[0]
 }
 
-func latestTF() TFCompatibility {
+func (r *Registry) latestTF() TFCompatibility {
 	var latest *TFCompatibility
-	for _, compat := range TFCompatibilityMatrix {
+	for _, compat := range r.TF {
 		if latest == nil {
 			latest = &compat
 		} else {
@@ -215,8 +193,8 @@ func versionGreater(a string, b string) (bool, error) {
 	return aVer.Greater(bVer), nil
 }
 
-func CUDABaseImageFor(cuda string, cuDNN string) (string, error) {
-	for _, image := range CUDABaseImages {
+func (r *Registry) CUDABaseImageFor(cuda string, cuDNN string) (string, error) {
+	for _, image := range r.CUDA {
 		if image.CUDA == cuda && image.CuDNN == cuDNN {
 			return image.ImageTag(), nil
 		}
@@ -224,8 +202,8 @@ func CUDABaseImageFor(cuda string, cuDNN string) (string, error) {
 	return "", fmt.Errorf("No matching base image for CUDA %s and CuDNN %s", cuda, cuDNN)
 }
 
-func tfCPUPackage(ver string) (name string, cpuVersion string, err error) {
-	for _, compat := range TFCompatibilityMatrix {
+func (r *Registry) tfCPUPackage(ver string) (name string, cpuVersion string, err error) {
+	for _, compat := range r.TF {
 		if compat.TF == ver {
 			return splitPythonPackage(compat.TFCPUPackage)
 		}
@@ -233,8 +211,8 @@ func tfCPUPackage(ver string) (name string, cpuVersion string, err error) {
 	return "", "", fmt.Errorf("No matching tensorflow CPU package for version %s", ver)
 }
 
-func tfGPUPackage(ver string, cuda string) (name string, cpuVersion string, err error) {
-	for _, compat := range TFCompatibilityMatrix {
+func (r *Registry) tfGPUPackage(ver string, cuda string) (name string, cpuVersion string, err error) {
+	for _, compat := range r.TF {
 		if compat.TF == ver && compat.CUDA == cuda {
 			return splitPythonPackage(compat.TFGPUPackage)
 		}
@@ -242,8 +220,8 @@ func tfGPUPackage(ver string, cuda string) (name string, cpuVersion string, err
 	return "", "", fmt.Errorf("No matching tensorflow GPU package for version %s and CUDA %s", ver, cuda)
 }
 
-func torchCPUPackage(ver string) (name string, cpuVersion string, indexURL string, err error) {
-	for _, compat := range TorchCompatibilityMatrix {
+func (r *Registry) torchCPUPackage(ver string) (name string, cpuVersion string, indexURL string, err error) {
+	for _, compat := range r.Torch {
 		if compat.TorchVersion() == ver && compat.CUDA == nil {
 			return "torch", compat.Torch, compat.IndexURL, nil
 		}
@@ -251,8 +229,8 @@ func torchCPUPackage(ver string) (name string, cpuVersion string, indexURL strin
 	return "", "", "", fmt.Errorf("No matching Torch CPU package for version %s", ver)
 }
 
-func torchGPUPackage(ver string, cuda string) (name string, cpuVersion string, indexURL string, err error) {
-	for _, compat := range TorchCompatibilityMatrix {
+func (r *Registry) torchGPUPackage(ver string, cuda string) (name string, cpuVersion string, indexURL string, err error) {
+	for _, compat := range r.Torch {
 		if compat.TorchVersion() == ver && compat.CUDA != nil && *compat.CUDA == cuda {
 			return "torch", compat.Torch, compat.IndexURL, nil
 		}
@@ -260,8 +238,8 @@ func torchGPUPackage(ver string, cuda string) (name string, cpuVersion string, i
 	return "", "", "", fmt.Errorf("No matching torch GPU package for version %s and CUDA %s", ver, cuda)
 }
 
-func torchvisionCPUPackage(ver string) (name string, cpuVersion string, indexURL string, err error) {
-	for _, compat := range TorchCompatibilityMatrix {
+func (r *Registry) torchvisionCPUPackage(ver string) (name string, cpuVersion string, indexURL string, err error) {
+	for _, compat := range r.Torch {
 		if compat.TorchvisionVersion() == ver && compat.CUDA == nil {
 			return "torchvision", compat.Torchvision, compat.IndexURL, nil
 		}
@@ -269,11 +247,11 @@ func torchvisionCPUPackage(ver string) (name string, cpuVersion string, indexURL
 	return "", "", "", fmt.Errorf("No matching torchvision CPU package for version %s", ver)
 }
 
-func torchvisionGPUPackage(ver string, cuda string) (name string, cpuVersion string, indexURL string, err error) {
-	for _, compat := range TorchCompatibilityMatrix {
+func (r *Registry) torchvisionGPUPackage(ver string, cuda string) (name string, cpuVersion string, indexURL string, err error) {
+	for _, compat := range r.Torch {
 		if compat.TorchvisionVersion() == ver && *compat.CUDA == cuda {
 			return "torchvision", compat.Torchvision, compat.IndexURL, nil
 		}
 	}
 	return "", "", "", fmt.Errorf("No matching torchvision GPU package for version %s and CUDA %s", ver, cuda)
-}
\ No newline at end of file
+}
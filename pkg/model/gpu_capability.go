@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/replicate/modelserver/pkg/version"
+)
+
+// gpuArchCapabilities maps a GPU architecture codename to its sm_XX.
+var gpuArchCapabilities = map[string]string{
+	"turing": "sm_75",
+	"ampere": "sm_80",
+	"hopper": "sm_90",
+}
+
+// ResolveGPUCapability turns an architecture codename or raw sm_XX string
+// into a canonical sm_XX string.
+func ResolveGPUCapability(name string) (string, error) {
+	if sm, ok := gpuArchCapabilities[strings.ToLower(name)]; ok {
+		return sm, nil
+	}
+	if strings.HasPrefix(name, "sm_") {
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown GPU capability or architecture: %s", name)
+}
+
+// CUDABaseImageForCapability picks the minimum CUDA/cuDNN base image whose
+// supported compute capabilities cover all of caps.
+func (r *Registry) CUDABaseImageForCapability(caps []string) (string, error) {
+	images := make([]CUDABaseImage, len(r.CUDA))
+	copy(images, r.CUDA)
+	sort.Slice(images, func(i, j int) bool {
+		a, b := images[i], images[j]
+		if a.CUDA != b.CUDA {
+			return version.MustVersion(b.CUDA).Greater(version.MustVersion(a.CUDA))
+		}
+		return version.MustVersion(b.CuDNN).Greater(version.MustVersion(a.CuDNN))
+	})
+	for _, image := range images {
+		if capabilitiesCoverAll(image.Capabilities, caps) {
+			return image.ImageTag(), nil
+		}
+	}
+	return "", fmt.Errorf("no CUDA base image supports compute capabilities %v", caps)
+}
+
+func capabilitiesCoverAll(supported []string, want []string) bool {
+	set := make(map[string]bool, len(supported))
+	for _, s := range supported {
+		set[s] = true
+	}
+	for _, w := range want {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package model
+
+import "testing"
+
+func TestResolveGPUCapability(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "ampere", want: "sm_80"},
+		{name: "Hopper", want: "sm_90"},
+		{name: "sm_86", want: "sm_86"},
+		{name: "rdna3", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := ResolveGPUCapability(c.name)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("ResolveGPUCapability(%q): expected error, got %q", c.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ResolveGPUCapability(%q): unexpected error: %s", c.name, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ResolveGPUCapability(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestCUDABaseImageForCapabilityPicksMinimumCUDA(t *testing.T) {
+	r := &Registry{
+		CUDA: []CUDABaseImage{
+			{Tag: "12.1.0-cudnn8-devel-ubuntu22.04", CUDA: "12.1", Capabilities: []string{"sm_75", "sm_80", "sm_90"}},
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8", Capabilities: []string{"sm_75", "sm_80"}},
+		},
+	}
+
+	tag, err := r.CUDABaseImageForCapability([]string{"sm_75"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Both images support sm_75, but 11.8 is the minimum that does.
+	want := "nvidia/cuda:11.8.0-cudnn8-devel-ubuntu22.04"
+	if tag != want {
+		t.Errorf("expected %s, got %s", want, tag)
+	}
+}
+
+func TestCUDABaseImageForCapabilityRequiresFullCoverage(t *testing.T) {
+	r := &Registry{
+		CUDA: []CUDABaseImage{
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8", Capabilities: []string{"sm_75", "sm_80"}},
+			{Tag: "12.1.0-cudnn8-devel-ubuntu22.04", CUDA: "12.1", Capabilities: []string{"sm_75", "sm_80", "sm_90"}},
+		},
+	}
+
+	tag, err := r.CUDABaseImageForCapability([]string{"sm_90"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "nvidia/cuda:12.1.0-cudnn8-devel-ubuntu22.04"
+	if tag != want {
+		t.Errorf("expected %s, got %s", want, tag)
+	}
+}
+
+func TestCUDABaseImageForCapabilityPrefersLowerCuDNNOnTie(t *testing.T) {
+	r := &Registry{
+		CUDA: []CUDABaseImage{
+			{Tag: "11.8.0-cudnn9-devel-ubuntu22.04", CUDA: "11.8", CuDNN: "9", Capabilities: []string{"sm_75"}},
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8", CuDNN: "8", Capabilities: []string{"sm_75"}},
+		},
+	}
+
+	tag, err := r.CUDABaseImageForCapability([]string{"sm_75"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "nvidia/cuda:11.8.0-cudnn8-devel-ubuntu22.04"
+	if tag != want {
+		t.Errorf("expected %s, got %s", want, tag)
+	}
+}
+
+func TestCUDABaseImageForCapabilityNoMatch(t *testing.T) {
+	r := &Registry{
+		CUDA: []CUDABaseImage{
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8", Capabilities: []string{"sm_75"}},
+		},
+	}
+	if _, err := r.CUDABaseImageForCapability([]string{"sm_90"}); err == nil {
+		t.Error("expected an error when no image covers the requested capabilities")
+	}
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/replicate/modelserver/pkg/version"
+)
+
+type JAXCompatibility struct {
+	JAX      string
+	JAXlib   string
+	IndexURL string
+	CUDAs    []string
+	CuDNNs   []string
+	Pythons  []string
+}
+
+//go:generate go run ../../cmd/generate_compatibility_matrices/main.go -jax-output jax_compatibility_matrix.json
+
+func (r *Registry) cudasFromJAX(ver string) ([]string, error) {
+	for _, compat := range r.JAX {
+		if ver == compat.JAX {
+			return compat.CUDAs, nil
+		}
+	}
+	return nil, fmt.Errorf("jax==%s doesn't have any compatible CUDA versions", ver)
+}
+
+// cuDNNFromJAX picks the latest cuDNN jaxlib declares support for that a
+// CUDA base image also exists for.
+func (r *Registry) cuDNNFromJAX(ver string, cuda string) (string, error) {
+	for _, compat := range r.JAX {
+		if ver != compat.JAX {
+			continue
+		}
+		for _, compatCUDA := range compat.CUDAs {
+			if compatCUDA != cuda {
+				continue
+			}
+			cuDNN := latestCuDNNFrom(intersectCuDNNs(compat.CuDNNs, r.compatibleCuDNNsForCUDA(cuda)))
+			if cuDNN == "" {
+				return "", fmt.Errorf("jax==%s doesn't have a matching cuDNN base image for CUDA %s", ver, cuda)
+			}
+			return cuDNN, nil
+		}
+	}
+	return "", fmt.Errorf("jax==%s doesn't support CUDA %s", ver, cuda)
+}
+
+func intersectCuDNNs(a []string, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func latestCuDNNFrom(cuDNNs []string) string {
+	latest := ""
+	for _, cuDNN := range cuDNNs {
+		if latest == "" || version.MustVersion(cuDNN).Greater(version.MustVersion(latest)) {
+			latest = cuDNN
+		}
+	}
+	return latest
+}
+
+func (r *Registry) jaxCPUPackage(ver string) (name string, cpuVersion string, indexURL string, err error) {
+	for _, compat := range r.JAX {
+		if compat.JAX == ver {
+			return "jax", compat.JAX, compat.IndexURL, nil
+		}
+	}
+	return "", "", "", fmt.Errorf("No matching jax CPU package for version %s", ver)
+}
+
+func (r *Registry) jaxGPUPackage(ver string, cuda string, cuDNN string) (name string, jaxlibVersion string, indexURL string, err error) {
+	for _, compat := range r.JAX {
+		if compat.JAX != ver {
+			continue
+		}
+		for _, compatCUDA := range compat.CUDAs {
+			if compatCUDA == cuda {
+				return "jaxlib", fmt.Sprintf("%s+cuda%scudnn%s", compat.JAXlib, cuda, cuDNN), compat.IndexURL, nil
+			}
+		}
+	}
+	return "", "", "", fmt.Errorf("No matching jaxlib GPU package for version %s, CUDA %s and CuDNN %s", ver, cuda, cuDNN)
+}
@@ -0,0 +1,69 @@
+package model
+
+import "testing"
+
+func testRegistryForJAX() *Registry {
+	return &Registry{
+		JAX: []JAXCompatibility{
+			{
+				JAX:      "0.4.23",
+				JAXlib:   "0.4.23",
+				IndexURL: "https://storage.googleapis.com/jax-releases/jax_cuda_releases.html",
+				CUDAs:    []string{"12.2"},
+				CuDNNs:   []string{"8"},
+			},
+		},
+		CUDA: []CUDABaseImage{
+			{Tag: "12.2.0-cudnn8-devel-ubuntu22.04", CUDA: "12.2", CuDNN: "8"},
+			{Tag: "12.2.0-cudnn9-devel-ubuntu22.04", CUDA: "12.2", CuDNN: "9"},
+		},
+	}
+}
+
+func TestCuDNNFromJAXRespectsDeclaredCuDNNs(t *testing.T) {
+	r := testRegistryForJAX()
+
+	cuDNN, err := r.cuDNNFromJAX("0.4.23", "12.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// A newer cudnn9 base image exists for CUDA 12.2, but jaxlib==0.4.23
+	// only declares support for cudnn8 — that's what should be returned.
+	if cuDNN != "8" {
+		t.Errorf("expected cuDNN 8, got %s", cuDNN)
+	}
+}
+
+func TestJaxGPUPackageUsesDeclaredCuDNN(t *testing.T) {
+	r := testRegistryForJAX()
+
+	cuDNN, err := r.cuDNNFromJAX("0.4.23", "12.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	name, jaxlibVersion, _, err := r.jaxGPUPackage("0.4.23", "12.2", cuDNN)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "jaxlib" {
+		t.Errorf("expected package name jaxlib, got %s", name)
+	}
+	want := "0.4.23+cuda12.2cudnn8"
+	if jaxlibVersion != want {
+		t.Errorf("expected jaxlib version %s, got %s", want, jaxlibVersion)
+	}
+}
+
+func TestCuDNNFromJAXNoMatchingBaseImage(t *testing.T) {
+	r := &Registry{
+		JAX: []JAXCompatibility{
+			{JAX: "0.4.23", CUDAs: []string{"12.2"}, CuDNNs: []string{"7"}},
+		},
+		CUDA: []CUDABaseImage{
+			{Tag: "12.2.0-cudnn8-devel-ubuntu22.04", CUDA: "12.2", CuDNN: "8"},
+		},
+	}
+	if _, err := r.cuDNNFromJAX("0.4.23", "12.2"); err == nil {
+		t.Error("expected an error when no base image matches jaxlib's declared cuDNNs")
+	}
+}
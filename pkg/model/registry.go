@@ -0,0 +1,111 @@
+package model
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Registry holds the deep learning framework and CUDA/cuDNN compatibility
+// matrices that drive base image and package resolution.
+type Registry struct {
+	TF         []TFCompatibility
+	Torch      []TorchCompatibility
+	JAX        []JAXCompatibility
+	CUDA       []CUDABaseImage
+	ROCm       []ROCmCompatibility
+	ROCmImages []ROCmBaseImage
+}
+
+//go:embed *.json
+var embeddedMatrices embed.FS
+
+var defaultRegistry *Registry
+
+func init() {
+	r, err := LoadRegistry(embeddedMatrices)
+	if err != nil {
+		log.Fatalf("Failed to load embedded compatibility matrices: %s", err)
+	}
+	defaultRegistry = r
+}
+
+// DefaultRegistry returns the Registry built from the compatibility
+// matrices embedded in the cog binary.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// LoadRegistry builds a Registry from matrix JSON files read out of fsys,
+// using the same file names as the embedded matrices
+// (tf_compatability_matrix.json, torch_compatability_matrix.json,
+// jax_compatibility_matrix.json, cuda_base_image_tags.json,
+// cuda_capabilities_matrix.json, rocm_compatibility_matrix.json and
+// rocm_base_image_tags.json). tf/torch/cuda-images are the original
+// matrices and must be present; the rest were added later, so fsys is
+// allowed to omit them (e.g. a COG_COMPAT_DIR with just an updated torch
+// matrix) and those fields are left empty rather than failing the load.
+func LoadRegistry(fsys fs.FS) (*Registry, error) {
+	r := &Registry{}
+	if err := loadMatrix(fsys, "tf_compatability_matrix.json", &r.TF); err != nil {
+		return nil, err
+	}
+	if err := loadMatrix(fsys, "torch_compatability_matrix.json", &r.Torch); err != nil {
+		return nil, err
+	}
+	if err := loadMatrix(fsys, "cuda_base_image_tags.json", &r.CUDA); err != nil {
+		return nil, err
+	}
+
+	if err := loadOptionalMatrix(fsys, "jax_compatibility_matrix.json", &r.JAX); err != nil {
+		return nil, err
+	}
+
+	var capabilities map[string][]string
+	if err := loadOptionalMatrix(fsys, "cuda_capabilities_matrix.json", &capabilities); err != nil {
+		return nil, err
+	}
+	for i := range r.CUDA {
+		r.CUDA[i].Capabilities = capabilities[r.CUDA[i].Tag]
+	}
+
+	if err := loadOptionalMatrix(fsys, "rocm_compatibility_matrix.json", &r.ROCm); err != nil {
+		return nil, err
+	}
+	if err := loadOptionalMatrix(fsys, "rocm_base_image_tags.json", &r.ROCmImages); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func loadMatrix(fsys fs.FS, name string, v interface{}) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// loadOptionalMatrix is like loadMatrix, but a missing file is left
+// unpopulated rather than treated as an error.
+func loadOptionalMatrix(fsys fs.FS, name string, v interface{}) error {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
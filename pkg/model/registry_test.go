@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadRegistryRequiresCoreMatrices(t *testing.T) {
+	if _, err := LoadRegistry(fstest.MapFS{}); err == nil {
+		t.Error("expected an error when the core tf/torch/cuda matrices are missing")
+	}
+}
+
+func TestLoadRegistryTreatsNewerMatricesAsOptional(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tf_compatability_matrix.json":    {Data: []byte(`[]`)},
+		"torch_compatability_matrix.json": {Data: []byte(`[]`)},
+		"cuda_base_image_tags.json":       {Data: []byte(`[]`)},
+		// jax, cuda_capabilities, and rocm files intentionally omitted,
+		// mimicking a COG_COMPAT_DIR with just an updated torch matrix.
+	}
+
+	r, err := LoadRegistry(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(r.JAX) != 0 || len(r.ROCm) != 0 || len(r.ROCmImages) != 0 {
+		t.Errorf("expected optional matrices to be empty, got %+v", r)
+	}
+}
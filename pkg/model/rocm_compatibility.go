@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ROCmCompatibility describes a torch release published under a ROCm wheel index.
+type ROCmCompatibility struct {
+	Torch    string
+	ROCm     string
+	IndexURL string
+	Pythons  []string
+}
+
+// ROCmBaseImage is a rocm/dev-ubuntu-* base image tag.
+type ROCmBaseImage struct {
+	Tag    string
+	ROCm   string
+	Ubuntu string
+}
+
+func (i *ROCmBaseImage) UnmarshalJSON(data []byte) error {
+	var tag string
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return err
+	}
+	parts := strings.SplitN(tag, "-ubuntu", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("Tag must be in the format <rocmVersion>-ubuntu<ubuntuVersion>. Invalid tag: %s", tag)
+	}
+	i.Tag = tag
+	i.ROCm = parts[0]
+	i.Ubuntu = parts[1]
+	return nil
+}
+
+func (i *ROCmBaseImage) ImageTag() string {
+	return fmt.Sprintf("rocm/dev-ubuntu-%s:%s", i.Ubuntu, i.ROCm)
+}
+
+//go:generate go run ../../cmd/generate_compatibility_matrices/main.go -rocm-output rocm_compatibility_matrix.json -rocm-images-output rocm_base_image_tags.json
+
+// TorchROCmPackage returns the torch wheel for ver built against rocm.
+func (r *Registry) TorchROCmPackage(ver string, rocm string) (name string, torchVersion string, indexURL string, err error) {
+	for _, compat := range r.ROCm {
+		if compat.Torch == ver && compat.ROCm == rocm {
+			return "torch", compat.Torch, compat.IndexURL, nil
+		}
+	}
+	return "", "", "", fmt.Errorf("No matching torch ROCm package for version %s and ROCm %s", ver, rocm)
+}
+
+// ROCmBaseImageFor returns the rocm/dev-ubuntu-* base image for rocm.
+func (r *Registry) ROCmBaseImageFor(rocm string) (string, error) {
+	for _, image := range r.ROCmImages {
+		if image.ROCm == rocm {
+			return image.ImageTag(), nil
+		}
+	}
+	return "", fmt.Errorf("No matching ROCm base image for ROCm %s", rocm)
+}
@@ -0,0 +1,61 @@
+package model
+
+import "testing"
+
+func testRegistryForROCm() *Registry {
+	return &Registry{
+		ROCm: []ROCmCompatibility{
+			{Torch: "2.2.0", ROCm: "6.0", IndexURL: "https://download.pytorch.org/whl/rocm6.0", Pythons: []string{"3.10"}},
+		},
+		ROCmImages: []ROCmBaseImage{
+			{Tag: "6.0-ubuntu22.04", ROCm: "6.0", Ubuntu: "22.04"},
+		},
+	}
+}
+
+func TestTorchROCmPackage(t *testing.T) {
+	r := testRegistryForROCm()
+
+	name, torchVersion, indexURL, err := r.TorchROCmPackage("2.2.0", "6.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "torch" || torchVersion != "2.2.0" || indexURL != "https://download.pytorch.org/whl/rocm6.0" {
+		t.Errorf("unexpected result: name=%s torchVersion=%s indexURL=%s", name, torchVersion, indexURL)
+	}
+
+	if _, _, _, err := r.TorchROCmPackage("2.2.0", "5.6"); err == nil {
+		t.Error("expected an error for an unsupported ROCm version")
+	}
+}
+
+func TestROCmBaseImageFor(t *testing.T) {
+	r := testRegistryForROCm()
+
+	tag, err := r.ROCmBaseImageFor("6.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "rocm/dev-ubuntu-22.04:6.0"
+	if tag != want {
+		t.Errorf("expected %s, got %s", want, tag)
+	}
+
+	if _, err := r.ROCmBaseImageFor("5.6"); err == nil {
+		t.Error("expected an error for an unsupported ROCm version")
+	}
+}
+
+func TestROCmBaseImageUnmarshalJSON(t *testing.T) {
+	var image ROCmBaseImage
+	if err := image.UnmarshalJSON([]byte(`"6.0-ubuntu22.04"`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if image.ROCm != "6.0" || image.Ubuntu != "22.04" {
+		t.Errorf("unexpected parse: ROCm=%s Ubuntu=%s", image.ROCm, image.Ubuntu)
+	}
+
+	if err := (&ROCmBaseImage{}).UnmarshalJSON([]byte(`"not-a-valid-tag"`)); err == nil {
+		t.Error("expected an error for a malformed tag")
+	}
+}
@@ -0,0 +1,208 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config is the subset of cog.yaml's build config that Validate checks.
+type Config struct {
+	CUDA          string
+	CuDNN         string
+	PythonVersion string
+	TensorFlow    string
+	Torch         string
+}
+
+// ValidationError is a single incompatible combination found by Validate,
+// with suggested fixes.
+type ValidationError struct {
+	Msg         string
+	Suggestions []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s. Suggestions: %s", e.Msg, strings.Join(e.Suggestions, "; "))
+}
+
+// Validate checks a user's requested cuda/cudnn/python_version/tensorflow/
+// torch pins against r's compatibility matrices and returns at most one
+// ValidationError per check (cuda/cudnn, torch, tensorflow, and torch and
+// tensorflow agreeing on CUDA). The tensorflow check joins all of its
+// conflicts into one error; the torch check stops at its first conflict.
+// Conflicting pins come with suggested fixes; errors for an unknown version
+// or a missing base image carry no suggestions.
+func (r *Registry) Validate(cfg *Config) []error {
+	var errs []error
+
+	if err := r.validateCUDA(cfg); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Torch != "" {
+		if err := r.validateTorch(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.TensorFlow != "" {
+		if err := r.validateTensorFlow(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := r.validateFrameworksAgreeOnCUDA(cfg); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// validateCUDA checks a bare cuda/cudnn pin against r.CUDA.
+func (r *Registry) validateCUDA(cfg *Config) error {
+	if cfg.CUDA == "" {
+		return nil
+	}
+	if cfg.CuDNN != "" {
+		if _, err := r.CUDABaseImageFor(cfg.CUDA, cfg.CuDNN); err != nil {
+			return &ValidationError{Msg: err.Error()}
+		}
+		return nil
+	}
+	if len(r.compatibleCuDNNsForCUDA(cfg.CUDA)) == 0 {
+		return &ValidationError{Msg: fmt.Sprintf("no base image exists for CUDA %s", cfg.CUDA)}
+	}
+	return nil
+}
+
+// validateFrameworksAgreeOnCUDA catches torch and tensorflow implying
+// disjoint CUDA versions when cfg.CUDA is left for cog to infer.
+func (r *Registry) validateFrameworksAgreeOnCUDA(cfg *Config) error {
+	if cfg.CUDA != "" || cfg.Torch == "" || cfg.TensorFlow == "" {
+		return nil
+	}
+	torchCUDAs, err := r.cudasFromTorch(cfg.Torch)
+	if err != nil {
+		return nil // already reported by validateTorch
+	}
+	tfCUDA, _, err := r.cudaFromTF(cfg.TensorFlow)
+	if err != nil {
+		return nil // already reported by validateTensorFlow
+	}
+	if containsString(torchCUDAs, tfCUDA) {
+		return nil
+	}
+	return &ValidationError{
+		Msg: fmt.Sprintf("torch==%s requires CUDA %s but tensorflow==%s requires CUDA %s", cfg.Torch, strings.Join(torchCUDAs, " or "), cfg.TensorFlow, tfCUDA),
+		Suggestions: []string{
+			fmt.Sprintf("set cuda: %q and pin a torch version compatible with it", tfCUDA),
+		},
+	}
+}
+
+func (r *Registry) validateTorch(cfg *Config) error {
+	cudas, err := r.cudasFromTorch(cfg.Torch)
+	if err != nil {
+		return &ValidationError{Msg: err.Error()}
+	}
+
+	if cfg.CUDA != "" {
+		found := false
+		for _, cuda := range cudas {
+			if cuda == cfg.CUDA {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &ValidationError{
+				Msg: fmt.Sprintf("torch==%s requires CUDA %s; you asked for %s", cfg.Torch, strings.Join(cudas, " or "), cfg.CUDA),
+				Suggestions: []string{
+					fmt.Sprintf("set cuda: %q", latestCUDAFrom(cudas)),
+					"pin a different torch version compatible with your requested CUDA",
+				},
+			}
+		}
+	}
+
+	if cfg.PythonVersion != "" {
+		pythons := r.pythonsFromTorch(cfg.Torch, cfg.CUDA)
+		if !containsString(pythons, cfg.PythonVersion) {
+			return &ValidationError{
+				Msg:         fmt.Sprintf("torch==%s doesn't support python %s", cfg.Torch, cfg.PythonVersion),
+				Suggestions: []string{fmt.Sprintf("set python_version to one of: %s", strings.Join(pythons, ", "))},
+			}
+		}
+	}
+
+	return nil
+}
+
+// pythonsFromTorch returns the pythons torch==ver supports, scoped to cuda
+// when given.
+func (r *Registry) pythonsFromTorch(ver string, cuda string) []string {
+	var pythons []string
+	for _, compat := range r.Torch {
+		if compat.TorchVersion() != ver {
+			continue
+		}
+		if cuda != "" && (compat.CUDA == nil || *compat.CUDA != cuda) {
+			continue
+		}
+		for _, python := range compat.Pythons {
+			if !containsString(pythons, python) {
+				pythons = append(pythons, python)
+			}
+		}
+	}
+	return pythons
+}
+
+func (r *Registry) validateTensorFlow(cfg *Config) error {
+	cuda, cuDNN, err := r.cudaFromTF(cfg.TensorFlow)
+	if err != nil {
+		return &ValidationError{Msg: err.Error()}
+	}
+
+	var conflicts []string
+	var suggestions []string
+	if cfg.CUDA != "" && cfg.CUDA != cuda {
+		conflicts = append(conflicts, fmt.Sprintf("requires CUDA %s; you asked for %s", cuda, cfg.CUDA))
+		suggestions = append(suggestions, fmt.Sprintf("set cuda: %q", cuda))
+	}
+	if cfg.CuDNN != "" && cfg.CuDNN != cuDNN {
+		conflicts = append(conflicts, fmt.Sprintf("requires CuDNN %s; you asked for %s", cuDNN, cfg.CuDNN))
+		suggestions = append(suggestions, fmt.Sprintf("set cudnn: %q", cuDNN))
+	}
+	if cfg.PythonVersion != "" {
+		pythons := r.pythonsFromTF(cfg.TensorFlow)
+		if !containsString(pythons, cfg.PythonVersion) {
+			conflicts = append(conflicts, fmt.Sprintf("doesn't support python %s", cfg.PythonVersion))
+			suggestions = append(suggestions, fmt.Sprintf("set python_version to one of: %s", strings.Join(pythons, ", ")))
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return &ValidationError{
+		Msg:         fmt.Sprintf("tensorflow==%s %s", cfg.TensorFlow, strings.Join(conflicts, "; ")),
+		Suggestions: suggestions,
+	}
+}
+
+func (r *Registry) pythonsFromTF(ver string) []string {
+	for _, compat := range r.TF {
+		if compat.TF == ver {
+			return compat.Pythons
+		}
+	}
+	return nil
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
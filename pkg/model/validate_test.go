@@ -0,0 +1,86 @@
+package model
+
+import "testing"
+
+func testRegistryForValidate() *Registry {
+	cuda21 := "11.8"
+	return &Registry{
+		Torch: []TorchCompatibility{
+			{Torch: "2.1.0", CUDA: &cuda21, Pythons: []string{"3.9", "3.10", "3.11"}},
+		},
+		TF: []TFCompatibility{
+			{TF: "2.13.0", CUDA: "11.8", CuDNN: "8", Pythons: []string{"3.9", "3.10", "3.11"}},
+		},
+		CUDA: []CUDABaseImage{
+			{Tag: "11.8.0-cudnn8-devel-ubuntu22.04", CUDA: "11.8", CuDNN: "8"},
+		},
+	}
+}
+
+func TestValidateRejectsUnsupportedPythonForTorch(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{Torch: "2.1.0", PythonVersion: "2.7"})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateAcceptsSupportedPythonForTorch(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{Torch: "2.1.0", CUDA: "11.8", PythonVersion: "3.10"})
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateRejectsUnsupportedPythonForTensorFlow(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{TensorFlow: "2.13.0", PythonVersion: "2.7"})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateAcceptsSupportedPythonForTensorFlow(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{TensorFlow: "2.13.0", PythonVersion: "3.11"})
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateRejectsDisjointFrameworkCUDAs(t *testing.T) {
+	r := testRegistryForValidate()
+	// torch==2.1.0 requires CUDA 11.8; add a tensorflow release that
+	// requires CUDA 12.1 to force a disjoint pair when cfg.CUDA is unset.
+	r.TF = append(r.TF, TFCompatibility{TF: "2.15.0", CUDA: "12.1", CuDNN: "8", Pythons: []string{"3.11"}})
+
+	errs := r.Validate(&Config{Torch: "2.1.0", TensorFlow: "2.15.0"})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateAcceptsOverlappingFrameworkCUDAs(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{Torch: "2.1.0", TensorFlow: "2.13.0"})
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateRejectsBareCUDAWithNoMatchingImage(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{CUDA: "99.9", CuDNN: "1"})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateAcceptsBareCUDAWithMatchingImage(t *testing.T) {
+	r := testRegistryForValidate()
+	errs := r.Validate(&Config{CUDA: "11.8", CuDNN: "8"})
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}